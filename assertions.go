@@ -0,0 +1,263 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// namedValue renders value alongside the source expression (name) that
+// produced it, the same way Equal renders its aValue/bValue pair.
+func namedValue(value interface{}, name string, colorful bool) string {
+	v := formatValue(value)
+	if v == name {
+		return v
+	}
+	if colorful {
+		return fmt.Sprintf("%s%s(%s)%s", name, colorType, v, colorNormal)
+	}
+	return fmt.Sprintf("%s(%s)", name, v)
+}
+
+// NotEqual asserts that a and b are not equal.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		a := greet("Mat")
+//		b := greet("Pat")
+//		is.NotEqual(a, b) // different greetings
+//	}
+//
+// If greet returns "Hi" for both, will output:
+//
+//	your_test.go:123: a(Hi) == b(Hi) // different greetings
+func (is *I) NotEqual(a, b interface{}) {
+	cmp := is.equal
+	if cmp == nil {
+		cmp = DefaultComparer
+	}
+	equal, _ := cmp(a, b)
+	if !equal {
+		return
+	}
+
+	argNames, _ := getArgNames()
+	aName := getElementFrom(argNames, 0, "")
+	bName := getElementFrom(argNames, 1, "")
+
+	is.report(Failure{
+		Assertion: "NotEqual",
+		Message:   fmt.Sprintf("%s == %s", namedValue(a, aName, is.colorful), namedValue(b, bName, is.colorful)),
+	})
+}
+
+// Contains asserts that container holds element: a substring of a
+// string, an element of a slice or array, or a key of a map.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.Contains([]string{"a", "b"}, "b") // b should be present
+//	}
+func (is *I) Contains(container, element interface{}) {
+	if containsElement(container, element) {
+		return
+	}
+
+	argNames, _ := getArgNames()
+	containerName := getElementFrom(argNames, 0, "")
+	elementName := getElementFrom(argNames, 1, "")
+
+	is.report(Failure{
+		Assertion: "Contains",
+		Message:   fmt.Sprintf("%s does not contain %s", namedValue(container, containerName, is.colorful), namedValue(element, elementName, is.colorful)),
+	})
+}
+
+func containsElement(container, element interface{}) bool {
+	if s, ok := container.(string); ok {
+		sub, ok := element.(string)
+		return ok && strings.Contains(s, sub)
+	}
+
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if defaultEqual(v.Index(i).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		key := reflect.ValueOf(element)
+		if !key.IsValid() || !key.Type().AssignableTo(v.Type().Key()) {
+			return false
+		}
+		return v.MapIndex(key).IsValid()
+	}
+	return false
+}
+
+// Len asserts that x (a string, slice, array, map or channel) has
+// length n.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.Len([]int{1, 2, 3}, 3) // three items expected
+//	}
+func (is *I) Len(x interface{}, n int) {
+	l, ok := lengthOf(x)
+	if ok && l == n {
+		return
+	}
+
+	argNames, _ := getArgNames()
+	xName := getElementFrom(argNames, 0, "")
+
+	if !ok {
+		is.report(Failure{Assertion: "Len", Message: fmt.Sprintf("%s has no length", xName)})
+		return
+	}
+
+	is.report(Failure{Assertion: "Len", Message: fmt.Sprintf("len(%s) == %d, want %d", xName, l, n)})
+}
+
+func lengthOf(x interface{}) (int, bool) {
+	if x == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// InDelta asserts that a and b are float64s within delta of each
+// other.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.InDelta(piEstimate, math.Pi, 0.001) // close enough
+//	}
+func (is *I) InDelta(a, b, delta float64) {
+	if math.Abs(a-b) <= delta {
+		return
+	}
+
+	argNames, _ := getArgNames()
+	aName := getElementFrom(argNames, 0, "")
+	bName := getElementFrom(argNames, 1, "")
+
+	is.report(Failure{Assertion: "InDelta", Message: fmt.Sprintf("%s != %s (delta %v > %v)", namedValue(a, aName, is.colorful), namedValue(b, bName, is.colorful), math.Abs(a-b), delta)})
+}
+
+// Panic asserts that fn panics when called.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.Panic(func() { mustParse("") }) // empty input is invalid
+//	}
+func (is *I) Panic(fn func()) {
+	if didPanic(fn) {
+		return
+	}
+	is.report(Failure{Assertion: "Panic", Message: "did not panic: $ARGS"})
+}
+
+// NotPanic asserts that fn does not panic when called.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.NotPanic(func() { mustParse("1") }) // valid input
+//	}
+func (is *I) NotPanic(fn func()) {
+	if !didPanic(fn) {
+		return
+	}
+	is.report(Failure{Assertion: "NotPanic", Message: "panicked: $ARGS"})
+}
+
+func didPanic(fn func()) (panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	fn()
+	return false
+}
+
+// ErrorIs asserts that errors.Is(err, target) is true.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		_, err := os.Open("missing")
+//		is.ErrorIs(err, os.ErrNotExist) // file shouldn't exist
+//	}
+func (is *I) ErrorIs(err, target error) {
+	if errors.Is(err, target) {
+		return
+	}
+	is.report(Failure{Assertion: "ErrorIs", Message: fmt.Sprintf("error: %s does not wrap %s", formatValue(err), formatValue(target))})
+}
+
+// ErrorAs asserts that errors.As(err, target) is true.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		var perr *os.PathError
+//		is.ErrorAs(err, &perr) // should be a path error
+//	}
+func (is *I) ErrorAs(err error, target interface{}) {
+	if errors.As(err, target) {
+		return
+	}
+	is.report(Failure{Assertion: "ErrorAs", Message: fmt.Sprintf("error: %s cannot be assigned to %T", formatValue(err), target)})
+}
+
+// Match asserts that s matches the regular expression pattern.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.Match(`^\d+$`, id) // id should be numeric
+//	}
+func (is *I) Match(pattern, s string) {
+	matched, err := regexp.MatchString(pattern, s)
+	if err == nil && matched {
+		return
+	}
+	if err != nil {
+		is.report(Failure{Assertion: "Match", Message: fmt.Sprintf("invalid pattern %q: %v", pattern, err)})
+		return
+	}
+	is.report(Failure{Assertion: "Match", Message: fmt.Sprintf("%s does not match %q", formatValue(s), pattern)})
+}
+
+// Eventually asserts that fn returns true within timeout, polling every
+// interval.
+//
+//	func Test(t *testing.T) {
+//		is := is.New(t)
+//		is.Eventually(func() bool {
+//			return jobDone()
+//		}, time.Second, 10*time.Millisecond) // job should finish quickly
+//	}
+func (is *I) Eventually(fn func() bool, timeout, interval time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if fn() {
+			return
+		}
+		if time.Now().After(deadline) {
+			is.report(Failure{Assertion: "Eventually", Message: fmt.Sprintf("condition not met within %v", timeout)})
+			return
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,137 @@
+package is
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func run(t *testing.T, name string, f func(is *I)) (failed bool, out string) {
+	t.Helper()
+	tt := &mockT{}
+	is := New(tt)
+	var buf bytes.Buffer
+	is.out = &buf
+	is.colorful = false
+	f(is)
+	return tt.failed, buf.String()
+}
+
+func TestNotEqual(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.NotEqual(1, 2) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.NotEqual(1, 1) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestContains(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.Contains("hello world", "world") }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, out := run(t, "", func(is *I) { is.Contains([]int{1, 2, 3}, 2) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, out := run(t, "", func(is *I) { is.Contains(map[string]int{"a": 1}, "a") }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.Contains([]int{1, 2, 3}, 4) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+// TestContainsMismatchedMapKeyType ensures a map container with an
+// element whose type doesn't match the map's key type reports a
+// failure instead of panicking.
+func TestContainsMismatchedMapKeyType(t *testing.T) {
+	if failed, _ := run(t, "", func(is *I) { is.Contains(map[string]int{"a": 1}, 5) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestLen(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.Len([]int{1, 2, 3}, 3) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.Len([]int{1, 2, 3}, 2) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestInDelta(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.InDelta(1.0001, 1.0, 0.001) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.InDelta(1.1, 1.0, 0.001) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestPanicAssertions(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.Panic(func() { panic("boom") }) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.Panic(func() {}) }); !failed {
+		t.Errorf("should fail")
+	}
+	if failed, out := run(t, "", func(is *I) { is.NotPanic(func() {}) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.NotPanic(func() { panic("boom") }) }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestErrorIsAs(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	wrapped := errorsWrap(sentinel)
+
+	if failed, out := run(t, "", func(is *I) { is.ErrorIs(wrapped, sentinel) }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.ErrorIs(wrapped, errors.New("other")) }); !failed {
+		t.Errorf("should fail")
+	}
+
+	_, err := os.Open("/does/not/exist")
+	if failed, out := run(t, "", func(is *I) {
+		var perr *os.PathError
+		is.ErrorAs(err, &perr)
+	}); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+}
+
+func errorsWrap(err error) error {
+	return &wrappedErr{err}
+}
+
+type wrappedErr struct{ err error }
+
+func (w *wrappedErr) Error() string { return "wrapped: " + w.err.Error() }
+func (w *wrappedErr) Unwrap() error { return w.err }
+
+func TestMatch(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) { is.Match(`^\d+$`, "12345") }); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) { is.Match(`^\d+$`, "abc") }); !failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestEventually(t *testing.T) {
+	if failed, out := run(t, "", func(is *I) {
+		is.Eventually(func() bool { return true }, time.Second, time.Millisecond)
+	}); failed {
+		t.Errorf("shouldn't fail: %s", out)
+	}
+	if failed, _ := run(t, "", func(is *I) {
+		is.Eventually(func() bool { return false }, 5*time.Millisecond, time.Millisecond)
+	}); !failed {
+		t.Errorf("should fail")
+	}
+}
@@ -0,0 +1,25 @@
+package is
+
+import "testing"
+
+// TestAliasedAndEmbeddedReceiver ensures $ARGS/variable-name extraction
+// still works when the *I value isn't a bare variable literally named
+// is: an aliased local (assert := is.New(t)) or a struct field reached
+// through a selector chain (s.is.True(...)).
+func TestAliasedAndEmbeddedReceiver(t *testing.T) {
+	arguments, ok := loadArguments("./testdata/aliased_test.go", 19)
+	if !ok {
+		t.Errorf("loadArguments: not ok for aliased receiver")
+	}
+	if arguments != `a == getB()` {
+		t.Errorf("loadArguments: bad arguments %s", arguments)
+	}
+
+	arguments, ok = loadArguments("./testdata/embedded_test.go", 23)
+	if !ok {
+		t.Errorf("loadArguments: not ok for embedded receiver")
+	}
+	if arguments != `a == getB()` {
+		t.Errorf("loadArguments: bad arguments %s", arguments)
+	}
+}
@@ -0,0 +1,170 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Equaler is implemented by types that know how to compare themselves
+// to another value. If either argument passed to Equal implements
+// Equaler, its Equal method is used instead of reflect.DeepEqual.
+//
+//	type Money struct{ Cents int }
+//	func (m Money) Equal(other interface{}) bool {
+//		o, ok := other.(Money)
+//		return ok && m.Cents == o.Cents
+//	}
+type Equaler interface {
+	Equal(other interface{}) bool
+}
+
+// Comparer compares a and b for equality. When it reports them unequal,
+// diff may hold a human-readable description of where they differ; if
+// non-empty, it is rendered on the failure line in place of the default
+// "a != b" dump produced by Equal.
+type Comparer func(a, b interface{}) (equal bool, diff string)
+
+// SetEqual overrides the Comparer used by Equal. Pass nil to restore
+// DefaultComparer.
+//
+//	assert := is.New(t)
+//	assert.SetEqual(is.DiffComparer) // get path-qualified diffs on failure
+func (is *I) SetEqual(cmp Comparer) {
+	is.equal = cmp
+}
+
+// DefaultComparer is the Comparer used when none has been set via
+// SetEqual. It reports a and b equal if either implements Equaler and
+// agrees, or if reflect.DeepEqual does; it never produces a diff
+// string, leaving Equal to render its usual "a != b" line.
+func DefaultComparer(a, b interface{}) (equal bool, diff string) {
+	return defaultEqual(a, b), ""
+}
+
+// DiffComparer wraps DefaultComparer's equality check, but on failure
+// walks the two values and reports the path at which they first
+// diverge (e.g. "[value]: 1 != 2") instead of dumping both containers
+// whole.
+func DiffComparer(a, b interface{}) (equal bool, diff string) {
+	if defaultEqual(a, b) {
+		return true, ""
+	}
+	return false, diffValue("", reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// defaultEqual gets whether a equals b or not.
+func defaultEqual(a, b interface{}) bool {
+	if isNil(a) || isNil(b) {
+		return isNil(a) == isNil(b)
+	}
+	if ea, ok := a.(Equaler); ok {
+		return ea.Equal(b)
+	}
+	if eb, ok := b.(Equaler); ok {
+		return eb.Equal(a)
+	}
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	aValue := reflect.ValueOf(a)
+	bValue := reflect.ValueOf(b)
+	if aValue.Type() != bValue.Type() {
+		return false
+	}
+	switch aValue.Kind() {
+	case reflect.Chan, reflect.Ptr, reflect.UnsafePointer:
+		// comparable, and reflect.DeepEqual already reports them equal
+		// when they are the same channel or pointer (or, for pointers,
+		// point at deeply equal values); compare the pointers directly
+		// rather than the reflect.Value structs themselves, which would
+		// never reflect the underlying data.
+		return aValue.Pointer() == bValue.Pointer()
+	case reflect.Func, reflect.Map, reflect.Slice:
+		// not comparable with ==, and reflect.DeepEqual already ruled
+		// out equality above.
+		return false
+	default:
+		// Struct and Array can still embed a Func/Map/Slice field, which
+		// makes == panic instead of just returning false. DeepEqual
+		// already ruled out equality above, so it's safe to bail out
+		// before risking that panic.
+		if !aValue.Type().Comparable() {
+			return false
+		}
+		return a == b
+	}
+}
+
+// diffValue walks a and b, assumed already known to differ, and
+// returns a description of the path at which they first diverge. It
+// only descends into matching map/slice/struct shapes; anything else
+// (including a nil interface, or a and b being different concrete
+// types) falls back to a plain "a != b" rendering rather than risking
+// a panic from treating mismatched shapes as if they matched.
+func diffValue(path string, a, b reflect.Value) string {
+	if a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return fmt.Sprintf("%s: %s != %s", strings.TrimPrefix(path, "."), formatValue(valueInterface(a)), formatValue(valueInterface(b)))
+	}
+
+	switch {
+	case a.Kind() == reflect.Map && b.Kind() == reflect.Map:
+		seen := map[interface{}]bool{}
+		for _, k := range a.MapKeys() {
+			seen[k.Interface()] = true
+		}
+		for _, k := range b.MapKeys() {
+			seen[k.Interface()] = true
+		}
+		for k := range seen {
+			kv := reflect.ValueOf(k)
+			av := a.MapIndex(kv)
+			bv := b.MapIndex(kv)
+			if !av.IsValid() {
+				return fmt.Sprintf("%s[%v]: <missing> != %s", path, k, formatValue(bv.Interface()))
+			}
+			if !bv.IsValid() {
+				return fmt.Sprintf("%s[%v]: %s != <missing>", path, k, formatValue(av.Interface()))
+			}
+			if !defaultEqual(av.Interface(), bv.Interface()) {
+				return diffValue(fmt.Sprintf("%s[%v]", path, k), av, bv)
+			}
+		}
+	case (a.Kind() == reflect.Slice || a.Kind() == reflect.Array) && (b.Kind() == reflect.Slice || b.Kind() == reflect.Array):
+		if a.Len() != b.Len() {
+			return fmt.Sprintf("%s: len %d != len %d", path, a.Len(), b.Len())
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !defaultEqual(a.Index(i).Interface(), b.Index(i).Interface()) {
+				return diffValue(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+			}
+		}
+	case a.Kind() == reflect.Struct && b.Kind() == reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			af, bf := a.Field(i), b.Field(i)
+			if !af.CanInterface() {
+				continue
+			}
+			if !defaultEqual(af.Interface(), bf.Interface()) {
+				return diffValue(path+"."+a.Type().Field(i).Name, af, bf)
+			}
+		}
+	}
+	return fmt.Sprintf("%s: %s != %s", strings.TrimPrefix(path, "."), formatValue(valueInterface(a)), formatValue(valueInterface(b)))
+}
+
+// valueInterface is reflect.Value.Interface, except it returns nil for
+// the zero Value instead of panicking.
+func valueInterface(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,109 @@
+package is
+
+import (
+	"bytes"
+	"testing"
+)
+
+type money struct{ cents int }
+
+func (m money) Equal(other interface{}) bool {
+	o, ok := other.(money)
+	return ok && m.cents == o.cents
+}
+
+func TestEqualerIsUsed(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	var buf bytes.Buffer
+	is.out = &buf
+	is.colorful = false
+
+	is.Equal(money{100}, money{100})
+	if tt.failed {
+		t.Errorf("shouldn't fail: %s", buf.String())
+	}
+
+	is.Equal(money{100}, money{200})
+	if !tt.failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestDiffComparer(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	is.SetEqual(DiffComparer)
+	var buf bytes.Buffer
+	is.out = &buf
+	is.colorful = false
+
+	m1 := map[string]int{"value": 1}
+	m2 := map[string]int{"value": 2}
+	is.Equal(m1, m2) // maps
+
+	if !tt.failed {
+		t.Errorf("should fail")
+	}
+	if got := buf.String(); !bytes.Contains([]byte(got), []byte("[value]: 1 != 2")) {
+		t.Errorf("expected path-qualified diff, got: %s", got)
+	}
+}
+
+// TestDiffComparerMismatchedShapes ensures DiffComparer falls back to a
+// plain "a != b" rendering instead of panicking when the two values
+// aren't the same concrete type - a nil vs a populated map, maps with
+// different key types, and structs with a different number of fields.
+func TestDiffComparerMismatchedShapes(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	is.SetEqual(DiffComparer)
+	is.Equal(nil, map[string]int{"x": 1}) // nil vs populated map
+
+	tt2 := &mockT{}
+	is2 := New(tt2)
+	is2.SetEqual(DiffComparer)
+	is2.Equal(map[string]int{"a": 1}, map[int]int{1: 1}) // different key types
+
+	type small struct{ A, B int }
+	type big struct{ A, B, C int }
+	tt3 := &mockT{}
+	is3 := New(tt3)
+	is3.SetEqual(DiffComparer)
+	is3.Equal(big{1, 2, 3}, small{1, 2}) // different struct shapes
+
+	if !tt.failed || !tt2.failed || !tt3.failed {
+		t.Errorf("all three comparisons should fail")
+	}
+}
+
+// TestDefaultEqualNonComparableStruct ensures comparing two structs that
+// embed a non-comparable field (e.g. a slice) reports them unequal
+// instead of panicking on the underlying == operator.
+func TestDefaultEqualNonComparableStruct(t *testing.T) {
+	type withSlice struct{ Items []int }
+	if defaultEqual(withSlice{[]int{1, 2}}, withSlice{[]int{1, 3}}) {
+		t.Errorf("different slices should not be equal")
+	}
+
+	tt := &mockT{}
+	is := New(tt)
+	var buf bytes.Buffer
+	is.out = &buf
+	is.colorful = false
+	is.Equal(withSlice{[]int{1, 2}}, withSlice{[]int{1, 3}})
+	if !tt.failed {
+		t.Errorf("should fail: %s", buf.String())
+	}
+}
+
+func TestDefaultEqualChans(t *testing.T) {
+	a := make(chan int)
+	b := make(chan int)
+	if defaultEqual(a, b) {
+		t.Errorf("different channels should not be equal")
+	}
+	if !defaultEqual(a, a) {
+		t.Errorf("same channel should be equal")
+	}
+}
@@ -0,0 +1,34 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestConcurrentASTCache fires many parallel subtests, each making
+// several assertions that touch the AST cache, to catch the
+// concurrent map write that astCacheMu and fileParseGroup guard
+// against. Run with -race to verify.
+func TestConcurrentASTCache(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		t.Run(fmt.Sprintf("sub%d", i), func(t *testing.T) {
+			t.Parallel()
+			for j := 0; j < 20; j++ {
+				if _, ok := loadComment("./testdata/example_test.go", 14); !ok {
+					t.Errorf("loadComment: not ok")
+				}
+				if _, ok := loadArguments("./testdata/example_test.go", 23); !ok {
+					t.Errorf("loadArguments: not ok")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetNodeFromCache(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			loadComment("./testdata/example_test.go", 14)
+		}
+	})
+}
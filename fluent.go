@@ -0,0 +1,158 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Matcher is a reusable, named assertion that can be passed to
+// Expectation.To. Match reports whether actual satisfies the matcher,
+// and if not, a message describing why.
+type Matcher[V any] interface {
+	Match(actual V) (ok bool, msg string)
+}
+
+// MatcherFunc adapts a plain function to a Matcher.
+type MatcherFunc[V any] func(actual V) (ok bool, msg string)
+
+// Match implements Matcher.
+func (f MatcherFunc[V]) Match(actual V) (bool, string) {
+	return f(actual)
+}
+
+// Equal returns a Matcher asserting that a value equals want, using
+// the same equality rules as (*I).Equal (Equaler, then
+// reflect.DeepEqual).
+//
+//	is.Expect(t, greet("Mat")).To(is.Equal("Hi Mat"))
+func Equal[V any](want V) Matcher[V] {
+	return MatcherFunc[V](func(actual V) (bool, string) {
+		if defaultEqual(actual, want) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%s != %s", formatValue(actual), formatValue(want))
+	})
+}
+
+// HasField returns a Matcher asserting that actual (a struct, or
+// pointer to one) has a field called name equal to want.
+//
+//	is.Expect[any](t, user).To(is.HasField("Name", "Mat"))
+func HasField(name string, want interface{}) Matcher[any] {
+	return MatcherFunc[any](func(actual any) (bool, string) {
+		f, ok := structField(actual, name)
+		if !ok {
+			return false, fmt.Sprintf("%s has no field %s", formatValue(actual), name)
+		}
+		if !defaultEqual(f, want) {
+			return false, fmt.Sprintf("%s.%s = %s, want %s", formatValue(actual), name, formatValue(f), formatValue(want))
+		}
+		return true, ""
+	})
+}
+
+// LenOf returns a Matcher asserting that actual (a string, slice,
+// array, map or channel) has length n.
+//
+//	is.Expect[any](t, items).To(is.LenOf(3))
+func LenOf(n int) Matcher[any] {
+	return MatcherFunc[any](func(actual any) (bool, string) {
+		l, ok := lengthOf(actual)
+		if !ok {
+			return false, fmt.Sprintf("%s has no length", formatValue(actual))
+		}
+		if l != n {
+			return false, fmt.Sprintf("len == %d, want %d", l, n)
+		}
+		return true, ""
+	})
+}
+
+// Expectation is a fluent assertion in progress, built by Expect.
+type Expectation[V any] struct {
+	is  *I
+	got V
+}
+
+// Expect starts a fluent assertion against got, reported through t.
+//
+//	is.Expect(t, add(2, 4)).To(is.Equal(6))
+func Expect[V any](t T, got V) *Expectation[V] {
+	return &Expectation[V]{is: New(t), got: got}
+}
+
+// To applies m to the expected value, failing the test if it doesn't
+// match.
+func (e *Expectation[V]) To(m Matcher[V]) *Expectation[V] {
+	if ok, msg := m.Match(e.got); !ok {
+		e.is.report(Failure{Assertion: "Expect", Message: msg})
+	}
+	return e
+}
+
+// ordered constrains That/Builder to types GreaterThan and LessThan
+// can compare with < and >.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Builder is a chainable assertion in progress, built by That.
+type Builder[V ordered] struct {
+	is    *I
+	value V
+}
+
+// That starts a chainable assertion against value, reported through t.
+//
+//	is.That(t, total).Equals(6).And().GreaterThan(0)
+func That[V ordered](t T, value V) *Builder[V] {
+	return &Builder[V]{is: New(t), value: value}
+}
+
+// And is a no-op that documents intent between chained assertions.
+func (b *Builder[V]) And() *Builder[V] {
+	return b
+}
+
+// Equals asserts that the value equals want.
+func (b *Builder[V]) Equals(want V) *Builder[V] {
+	if b.value != want {
+		b.is.report(Failure{Assertion: "That.Equals", Message: fmt.Sprintf("%v != %v", b.value, want)})
+	}
+	return b
+}
+
+// GreaterThan asserts that the value is greater than want.
+func (b *Builder[V]) GreaterThan(want V) *Builder[V] {
+	if !(b.value > want) {
+		b.is.report(Failure{Assertion: "That.GreaterThan", Message: fmt.Sprintf("%v <= %v", b.value, want)})
+	}
+	return b
+}
+
+// LessThan asserts that the value is less than want.
+func (b *Builder[V]) LessThan(want V) *Builder[V] {
+	if !(b.value < want) {
+		b.is.report(Failure{Assertion: "That.LessThan", Message: fmt.Sprintf("%v >= %v", b.value, want)})
+	}
+	return b
+}
+
+// structField returns the value of the named field of v, which must
+// be a struct or pointer to one.
+func structField(v interface{}, name string) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	f := rv.FieldByName(name)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil, false
+	}
+	return f.Interface(), true
+}
@@ -0,0 +1,55 @@
+package is
+
+import "testing"
+
+type user struct {
+	Name string
+}
+
+func TestExpect(t *testing.T) {
+	tt := &mockT{}
+	Expect(tt, 1+1).To(Equal(2))
+	if tt.failed {
+		t.Errorf("shouldn't fail")
+	}
+
+	tt2 := &mockT{}
+	Expect(tt2, 1+1).To(Equal(3))
+	if !tt2.failed {
+		t.Errorf("should fail")
+	}
+}
+
+func TestExpectMatchers(t *testing.T) {
+	tt := &mockT{}
+	Expect[any](tt, user{Name: "Mat"}).To(HasField("Name", "Mat"))
+	if tt.failed {
+		t.Errorf("shouldn't fail")
+	}
+
+	tt2 := &mockT{}
+	Expect[any](tt2, user{Name: "Mat"}).To(HasField("Name", "Pat"))
+	if !tt2.failed {
+		t.Errorf("should fail")
+	}
+
+	tt3 := &mockT{}
+	Expect[any](tt3, []int{1, 2, 3}).To(LenOf(3))
+	if tt3.failed {
+		t.Errorf("shouldn't fail")
+	}
+}
+
+func TestThatBuilder(t *testing.T) {
+	tt := &mockT{}
+	That(tt, 6).Equals(6).And().GreaterThan(0).And().LessThan(10)
+	if tt.failed {
+		t.Errorf("shouldn't fail")
+	}
+
+	tt2 := &mockT{}
+	That(tt2, 6).Equals(5)
+	if !tt2.failed {
+		t.Errorf("should fail")
+	}
+}
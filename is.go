@@ -51,6 +51,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -73,6 +74,8 @@ type I struct {
 	fail     func()
 	out      io.Writer
 	colorful bool
+	equal    Comparer
+	reporter Reporter
 }
 
 type cacheEntryType string
@@ -85,9 +88,58 @@ var errNoCallerInfoFound = errors.New("could not find args")
 // global fileset
 var fset = token.NewFileSet()
 
-// astCache is a map of file[CacheEntryType:linenumber[Node]]
+// astCache is a map of file[CacheEntryType:linenumber[Node]]. It is
+// populated lazily, on the first assertion that touches a given file,
+// so concurrent (e.g. t.Parallel) tests may race to parse the same
+// file; astCacheMu and fileParseGroup guard against that.
 var astCache = make(map[string]map[string]ast.Node)
 
+// astCacheMu guards astCache itself. parsing a new file takes no more
+// than a read lock to check the cache and a write lock to install the
+// result; the parse work happens outside the lock, coordinated by
+// fileParseGroup so it only happens once per file.
+var astCacheMu sync.RWMutex
+
+// fileParseGroup deduplicates concurrent parses of the same
+// not-yet-cached file: if two goroutines ask for the same path at the
+// same time, only one calls parser.ParseFile; the other waits for and
+// reuses its result.
+var fileParseGroup = &fileGroup{calls: make(map[string]*fileCall)}
+
+type fileCall struct {
+	done chan struct{}
+	file map[string]ast.Node
+	err  error
+}
+
+type fileGroup struct {
+	mu    sync.Mutex
+	calls map[string]*fileCall
+}
+
+// do runs fn for key, unless another goroutine is already running it,
+// in which case it waits for that call's result instead.
+func (g *fileGroup) do(key string, fn func() (map[string]ast.Node, error)) (map[string]ast.Node, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.file, c.err
+	}
+	c := &fileCall{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.file, c.err = fn()
+	close(c.done)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.file, c.err
+}
+
 var noColorFlag bool
 
 func init() {
@@ -99,7 +151,7 @@ func init() {
 // In strict mode, failures call T.FailNow causing the test
 // to be aborted. See NewRelaxed for alternative behavior.
 func New(t T) *I {
-	return &I{t, t.FailNow, os.Stdout, !noColorFlag}
+	return &I{t, t.FailNow, os.Stdout, !noColorFlag, nil, nil}
 }
 
 // NewRelaxed makes a new testing helper using the specified
@@ -107,17 +159,43 @@ func New(t T) *I {
 // In relaxed mode, failures call T.Fail allowing
 // multiple failures per test.
 func NewRelaxed(t T) *I {
-	return &I{t, t.Fail, os.Stdout, !noColorFlag}
+	return &I{t, t.Fail, os.Stdout, !noColorFlag, nil, nil}
 }
 
-func (is *I) log(args ...interface{}) {
-	s := is.decorate(fmt.Sprint(args...))
-	fmt.Fprintf(is.out, s)
-	is.fail()
-}
+// report fills in the call-site details of f (file, line, func, args,
+// comment) and hands it to the active Reporter, defaulting to
+// TextReporter, before marking the test as failed.
+func (is *I) report(f Failure) {
+	path, line, funcName, ok := callerinfo()
+	if ok {
+		file := filepath.Base(path)
+		if index := strings.LastIndex(file, "/"); index >= 0 {
+			file = file[index+1:]
+		} else if index = strings.LastIndex(file, "\\"); index >= 0 {
+			file = file[index+1:]
+		}
+		f.File = file
+		f.Line = line
+		f.Func = funcName
+		if args, argsOK := loadArguments(path, line); argsOK {
+			f.Args = args
+		}
+		f.Comment, _ = loadComment(path, line)
+	} else {
+		f.File = "???"
+		f.Line = 1
+	}
+
+	if strings.Contains(f.Message, "$ARGS") {
+		f.Message = strings.Replace(f.Message, "$ARGS", f.Args, -1)
+	}
 
-func (is *I) logf(format string, args ...interface{}) {
-	is.log(fmt.Sprintf(format, args...))
+	reporter := is.reporter
+	if reporter == nil {
+		reporter = TextReporter{}
+	}
+	reporter.Report(is, f)
+	is.fail()
 }
 
 // Fail immediately fails the test.
@@ -130,7 +208,7 @@ func (is *I) logf(format string, args ...interface{}) {
 // In relaxed mode, execution will continue after a call to
 // Fail, but that test will still fail.
 func (is *I) Fail() {
-	is.log("failed")
+	is.report(Failure{Assertion: "Fail", Message: "failed"})
 }
 
 // True asserts that the expression is true. The expression
@@ -147,7 +225,7 @@ func (is *I) Fail() {
 //	your_test.go:123: not true: val != nil
 func (is *I) True(expression bool) {
 	if !expression {
-		is.log("not true: $ARGS")
+		is.report(Failure{Assertion: "True", Message: "not true: $ARGS"})
 	}
 }
 
@@ -163,7 +241,16 @@ func (is *I) True(expression bool) {
 //
 //	your_test.go:123: Hey Mat != Hi Mat // greeting
 func (is *I) Equal(a, b interface{}) {
-	if areEqual(a, b) {
+	cmp := is.equal
+	if cmp == nil {
+		cmp = DefaultComparer
+	}
+	equal, diff := cmp(a, b)
+	if equal {
+		return
+	}
+	if diff != "" {
+		is.report(Failure{Assertion: "Equal", Message: diff, Expected: formatValue(a), Actual: formatValue(b), TypeA: fmt.Sprintf("%T", a), TypeB: fmt.Sprintf("%T", b)})
 		return
 	}
 
@@ -200,8 +287,14 @@ func (is *I) Equal(a, b interface{}) {
 	// 	bValue = fmt.Sprintf("%s<%s>", bName, bValue)
 	// }
 
-	is.logf("%s != %s", aValue, bValue)
-
+	is.report(Failure{
+		Assertion: "Equal",
+		Message:   fmt.Sprintf("%s != %s", aValue, bValue),
+		Expected:  formatValue(a),
+		Actual:    formatValue(b),
+		TypeA:     aType,
+		TypeB:     bType,
+	})
 }
 
 func formatValue(object interface{}) string {
@@ -327,7 +420,7 @@ func (is *I) NoErr(err error) {
 
 	args, argsErr := getArgExprs()
 	if argsErr != nil || args == nil || len(args) <= 0 {
-		is.logf("%v", err)
+		is.report(Failure{Assertion: "NoErr", Message: fmt.Sprintf("%v", err), Actual: formatValue(err)})
 		return
 	}
 
@@ -352,8 +445,7 @@ func (is *I) NoErr(err error) {
 		errStr = fmt.Sprintf("error: %s(%s)", formatValue(err), errSrc)
 	}
 
-	is.logf(errStr)
-
+	is.report(Failure{Assertion: "NoErr", Message: errStr, Actual: formatValue(err), Args: errSrc})
 }
 
 // isNil gets whether the object is nil or not.
@@ -369,27 +461,8 @@ func isNil(object interface{}) bool {
 	return false
 }
 
-// areEqual gets whether a equals b or not.
-func areEqual(a, b interface{}) bool {
-	if isNil(a) || isNil(b) {
-		if isNil(a) && !isNil(b) {
-			return false
-		}
-		if !isNil(a) && isNil(b) {
-			return false
-		}
-		return true
-	}
-	if reflect.DeepEqual(a, b) {
-		return true
-	}
-	aValue := reflect.ValueOf(a)
-	bValue := reflect.ValueOf(b)
-	return aValue == bValue
-}
-
 func getArgExprs() ([]ast.Expr, error) {
-	path, lineNumber, ok := callerinfo()
+	path, lineNumber, _, ok := callerinfo()
 	if !ok {
 		return nil, errNoCallerInfoFound
 	}
@@ -422,43 +495,124 @@ func getArgNames() ([]string, error) {
 	return result, nil
 }
 
-func callerinfo() (path string, line int, ok bool) {
+// libraryFiles are this package's own implementation files. callerinfo
+// skips frames in them to find the first frame outside the library -
+// the actual call site of the failing assertion. It's keyed on base
+// name, not just "is.go", because the implementation is no longer a
+// single file; it deliberately excludes *_test.go, since tests in this
+// package (e.g. is_test.go) call assertions directly and are valid
+// call sites in their own right.
+var libraryFiles = map[string]bool{
+	"is.go":         true,
+	"comparer.go":   true,
+	"reporter.go":   true,
+	"assertions.go": true,
+	"fluent.go":     true,
+}
+
+func callerinfo() (path string, line int, funcName string, ok bool) {
 	for i := 0; ; i++ {
-		_, path, line, ok = runtime.Caller(i)
+		var pc uintptr
+		pc, path, line, ok = runtime.Caller(i)
 		if !ok {
 			return
 		}
-		if strings.HasSuffix(path, "is.go") {
+		if libraryFiles[filepath.Base(path)] {
 			continue
 		}
-		return path, line, true
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			funcName = fn.Name()
+		}
+		return
 	}
 }
 
-func getFileCache(path string) (map[string]ast.Node, bool) {
-	file, ok := astCache[path]
-	if ok {
-		return file, false
+// cacheKeyForFile keys astCache on path plus mtime, so a file edited
+// between test runs (e.g. by `go test -count=N` in watch mode) parses
+// fresh instead of returning a stale cached AST.
+func cacheKeyForFile(path string) string {
+	if info, err := os.Stat(path); err == nil {
+		return fmt.Sprintf("%s@%d", path, info.ModTime().UnixNano())
 	}
+	return path
+}
 
-	result := make(map[string]ast.Node)
-	astCache[path] = result
-	return result, true
-
+// getFileCache returns the already-parsed per-file cache for path, if
+// there is one.
+func getFileCache(path string) (map[string]ast.Node, bool) {
+	astCacheMu.RLock()
+	defer astCacheMu.RUnlock()
+	file, ok := astCache[cacheKeyForFile(path)]
+	return file, ok
 }
 
 func getNodeFromCache(kind cacheEntryType, path string, line int) (ast.Node, error) {
 	key := fmt.Sprintf("%s:%d", kind, line)
-	fileCache, newCacheEntry := getFileCache(path)
-	entry, ok := fileCache[key]
-	if ok {
-		return entry, nil
+
+	fileCache, ok := getFileCache(path)
+	if !ok {
+		var err error
+		fileCache, err = fileParseGroup.do(cacheKeyForFile(path), func() (map[string]ast.Node, error) {
+			// another goroutine may have parsed (and cached) this
+			// file while we waited to enter the singleflight group.
+			if cache, ok := getFileCache(path); ok {
+				return cache, nil
+			}
+			return parseFileToCache(path)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if !newCacheEntry {
+	entry, ok := fileCache[key]
+	if !ok {
 		return nil, fmt.Errorf("key %s not found in cache", key)
 	}
 
+	return entry, nil
+}
+
+// assertionArgCounts is the number of arguments each exported *I
+// assertion method takes. looksLikeIsCall uses it, together with the
+// method name, as a heuristic for "this selector call is an *I
+// assertion" that doesn't depend on the receiver being a variable
+// literally named is - it also matches an aliased receiver
+// (assert := is.New(t)) or an embedded/struct field one (s.is.Equal(...)).
+var assertionArgCounts = map[string]int{
+	"Fail":       0,
+	"True":       1,
+	"Equal":      2,
+	"NoErr":      1,
+	"NotEqual":   2,
+	"Contains":   2,
+	"Len":        2,
+	"InDelta":    3,
+	"Panic":      1,
+	"NotPanic":   1,
+	"ErrorIs":    2,
+	"ErrorAs":    2,
+	"Match":      2,
+	"Eventually": 3,
+}
+
+// looksLikeIsCall reports whether selExpr.Sel names an *I assertion
+// method and argCount is the number of arguments it takes. The
+// receiver expression (selExpr.X) is deliberately not inspected, so
+// this matches regardless of what the *I value is called or how it's
+// reached (plain variable, alias, or struct field).
+func looksLikeIsCall(selExpr *ast.SelectorExpr, argCount int) bool {
+	want, ok := assertionArgCounts[selExpr.Sel.Name]
+	return ok && want == argCount
+}
+
+// parseFileToCache parses path once, extracting every comment and
+// every call expression that looksLikeIsCall accepts by line number,
+// whatever its receiver, and installs the
+// result in astCache.
+func parseFileToCache(path string) (map[string]ast.Node, error) {
+	fileCache := make(map[string]ast.Node)
+
 	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
 	if err != nil {
 		return nil, err
@@ -490,12 +644,7 @@ func getNodeFromCache(kind cacheEntryType, path string, line int) (ast.Node, err
 			return true
 		}
 
-		ident, ok := selExpr.X.(*ast.Ident)
-		if !ok {
-			return true
-		}
-
-		if ident.Name != "is" {
+		if !looksLikeIsCall(selExpr, len(callExpr.Args)) {
 			return true
 		}
 
@@ -504,12 +653,11 @@ func getNodeFromCache(kind cacheEntryType, path string, line int) (ast.Node, err
 		return false
 	})
 
-	entry, ok = fileCache[key]
-	if !ok {
-		return nil, fmt.Errorf("key %s not found in cache", key)
-	}
+	astCacheMu.Lock()
+	astCache[cacheKeyForFile(path)] = fileCache
+	astCacheMu.Unlock()
 
-	return entry, nil
+	return fileCache, nil
 }
 
 func nodeToStr(fset *token.FileSet, node ast.Node) string {
@@ -521,6 +669,14 @@ func nodeToStr(fset *token.FileSet, node ast.Node) string {
 	return string(buf.Bytes())
 }
 
+// single-arg assertions whose $ARGS placeholder should expand to the
+// source of their one argument.
+var singleArgAssertions = map[string]bool{
+	"True":     true,
+	"Panic":    true,
+	"NotPanic": true,
+}
+
 func formatCallExprArgs(fset *token.FileSet, callExpr *ast.CallExpr) string {
 	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
 	if !ok {
@@ -528,13 +684,11 @@ func formatCallExprArgs(fset *token.FileSet, callExpr *ast.CallExpr) string {
 	}
 
 	result := ""
-	if selExpr.Sel.Name == "True" {
-		// true has only one arg
+	if singleArgAssertions[selExpr.Sel.Name] && len(callExpr.Args) == 1 {
 		arg := callExpr.Args[0]
 		result = nodeToStr(fset, arg)
 	}
 
-	// only true is currentley supported
 	return result
 }
 
@@ -576,64 +730,6 @@ func loadArguments(path string, line int) (string, bool) {
 	return argStr, true
 }
 
-// decorate prefixes the string with the file and line of the call site
-// and inserts the final newline if needed and indentation tabs for formatting.
-// this function was copied from the testing framework and modified.
-func (is *I) decorate(s string) string {
-	path, lineNumber, ok := callerinfo() // decorate + log + public function.
-	file := filepath.Base(path)
-	if ok {
-		// Truncate file name at last file name separator.
-		if index := strings.LastIndex(file, "/"); index >= 0 {
-			file = file[index+1:]
-		} else if index = strings.LastIndex(file, "\\"); index >= 0 {
-			file = file[index+1:]
-		}
-	} else {
-		file = "???"
-		lineNumber = 1
-	}
-	buf := new(bytes.Buffer)
-	// Every line is indented at least one tab.
-	buf.WriteByte('\t')
-	if is.colorful {
-		buf.WriteString(colorFile)
-	}
-	fmt.Fprintf(buf, "%s:%d: ", file, lineNumber)
-	if is.colorful {
-		buf.WriteString(colorNormal)
-	}
-	lines := strings.Split(s, "\n")
-	if l := len(lines); l > 1 && lines[l-1] == "" {
-		lines = lines[:l-1]
-	}
-	for i, line := range lines {
-		if i > 0 {
-			// Second and subsequent lines are indented an extra tab.
-			buf.WriteString("\n\t\t")
-		}
-		// expand arguments (if $ARGS is present)
-		if strings.Contains(line, "$ARGS") {
-			args, _ := loadArguments(path, lineNumber)
-			line = strings.Replace(line, "$ARGS", args, -1)
-		}
-		buf.WriteString(line)
-	}
-	comment, ok := loadComment(path, lineNumber)
-	if ok {
-		if is.colorful {
-			buf.WriteString(colorComment)
-		}
-		buf.WriteString(" // ")
-		buf.WriteString(comment)
-		if is.colorful {
-			buf.WriteString(colorNormal)
-		}
-	}
-	buf.WriteString("\n")
-	return buf.String()
-}
-
 const (
 	colorNormal  = "\u001b[39m"
 	colorComment = "\u001b[32m"
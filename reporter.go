@@ -0,0 +1,131 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Failure is a structured record of a single assertion failure. It is
+// built by report and handed to the active Reporter, so reporters can
+// render or forward it without reparsing is's output.
+type Failure struct {
+	File      string // base name of the file containing the call site
+	Line      int    // line number of the call site
+	Func      string // fully qualified name of the function containing the call site
+	Assertion string // name of the failing assertion, e.g. "Equal", "NoErr", "Contains" or "That.Equals"
+	Args      string // source of the asserted expression(s), when available
+	Expected  string // formatted a, for Equal
+	Actual    string // formatted b, for Equal and NoErr
+	TypeA     string // %T of a, for Equal
+	TypeB     string // %T of b, for Equal
+	Comment   string // trailing "// comment" on the assertion line
+	Message   string // the rendered one-line failure, e.g. "1 != 2"
+}
+
+// Reporter renders a Failure. SetReporter installs one on an *I; the
+// zero value uses TextReporter, preserving is's original output.
+type Reporter interface {
+	Report(is *I, f Failure)
+}
+
+// SetReporter overrides the Reporter used to render failures. Pass nil
+// to restore TextReporter.
+//
+//	assert := is.New(t)
+//	assert.SetReporter(is.JSONReporter{})
+func (is *I) SetReporter(r Reporter) {
+	is.reporter = r
+}
+
+// Format selects one of the built-in Reporters for use with SetFormat.
+type Format int
+
+const (
+	// FormatText renders failures as the original human-readable
+	// "file:line: message // comment" line. It is the default.
+	FormatText Format = iota
+	// FormatJSON renders each failure as a single line of JSON.
+	FormatJSON
+	// FormatTAP renders failures as TAP "not ok" lines with a YAML
+	// diagnostic block.
+	FormatTAP
+)
+
+// SetFormat installs one of the built-in Reporters by name; it is a
+// convenience wrapper around SetReporter.
+func (is *I) SetFormat(format Format) {
+	switch format {
+	case FormatJSON:
+		is.reporter = JSONReporter{}
+	case FormatTAP:
+		is.reporter = TAPReporter{}
+	default:
+		is.reporter = TextReporter{}
+	}
+}
+
+// TextReporter is the default Reporter. It reproduces is's original
+// "file:line: message // comment" human-readable output.
+type TextReporter struct{}
+
+// Report implements Reporter.
+func (TextReporter) Report(is *I, f Failure) {
+	buf := new(bytes.Buffer)
+	// Every line is indented at least one tab.
+	buf.WriteByte('\t')
+	if is.colorful {
+		buf.WriteString(colorFile)
+	}
+	fmt.Fprintf(buf, "%s:%d: ", f.File, f.Line)
+	if is.colorful {
+		buf.WriteString(colorNormal)
+	}
+	lines := strings.Split(f.Message, "\n")
+	if l := len(lines); l > 1 && lines[l-1] == "" {
+		lines = lines[:l-1]
+	}
+	for i, line := range lines {
+		if i > 0 {
+			// Second and subsequent lines are indented an extra tab.
+			buf.WriteString("\n\t\t")
+		}
+		buf.WriteString(line)
+	}
+	if f.Comment != "" {
+		if is.colorful {
+			buf.WriteString(colorComment)
+		}
+		buf.WriteString(" // ")
+		buf.WriteString(f.Comment)
+		if is.colorful {
+			buf.WriteString(colorNormal)
+		}
+	}
+	buf.WriteString("\n")
+	fmt.Fprint(is.out, buf.String())
+}
+
+// JSONReporter renders each failure as a single line of JSON, for
+// consumption by `go test -json` style tooling, CI dashboards and IDE
+// test panels.
+type JSONReporter struct{}
+
+// Report implements Reporter.
+func (JSONReporter) Report(is *I, f Failure) {
+	fmt.Fprintf(is.out, "{\"file\":%q,\"line\":%d,\"func\":%q,\"assertion\":%q,\"args\":%q,\"expected\":%q,\"actual\":%q,\"comment\":%q,\"type_a\":%q,\"type_b\":%q}\n",
+		f.File, f.Line, f.Func, f.Assertion, f.Args, f.Expected, f.Actual, f.Comment, f.TypeA, f.TypeB)
+}
+
+// TAPReporter renders each failure as a TAP (Test Anything Protocol)
+// "not ok" line with a YAML diagnostic block.
+type TAPReporter struct{}
+
+// Report implements Reporter.
+func (TAPReporter) Report(is *I, f Failure) {
+	name := f.Assertion
+	if f.Comment != "" {
+		name = fmt.Sprintf("%s - %s", name, f.Comment)
+	}
+	fmt.Fprintf(is.out, "not ok - %s\n  ---\n  file: %s:%d\n  message: %s\n  ...\n", name, f.File, f.Line, f.Message)
+}
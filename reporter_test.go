@@ -0,0 +1,61 @@
+package is
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONReporter(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	is.SetFormat(FormatJSON)
+	var buf bytes.Buffer
+	is.out = &buf
+
+	a, b := 1, 2
+	is.Equal(a, b) // expect to be the same
+
+	out := buf.String()
+	if !tt.failed {
+		t.Errorf("should fail")
+	}
+	for _, want := range []string{`"assertion":"Equal"`, `"expected":"1"`, `"actual":"2"`, `"comment":"expect to be the same"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected JSON output to contain %s, got: %s", want, out)
+		}
+	}
+}
+
+func TestTAPReporter(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	is.SetFormat(FormatTAP)
+	var buf bytes.Buffer
+	is.out = &buf
+
+	is.True(1 == 2)
+
+	out := buf.String()
+	if !tt.failed {
+		t.Errorf("should fail")
+	}
+	if !strings.HasPrefix(out, "not ok") {
+		t.Errorf("expected TAP output to start with 'not ok', got: %s", out)
+	}
+}
+
+func TestSetFormatDefaultsToText(t *testing.T) {
+	tt := &mockT{}
+	is := New(tt)
+	is.colorful = false
+	is.SetFormat(FormatText)
+	var buf bytes.Buffer
+	is.out = &buf
+
+	is.Fail()
+
+	if !strings.Contains(buf.String(), "failed") {
+		t.Errorf("expected text output, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,20 @@
+package example
+
+// CAUTION: DO NOT EDIT
+// Tests in this project rely on specific lines numbers
+// throughout this file.
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAliasedReceiver(t *testing.T) {
+	assert := is.New(t)
+	a, b := 1, 2
+	getB := func() int {
+		return b
+	}
+	assert.True(a == getB()) // should be the same
+}
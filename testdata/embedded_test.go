@@ -0,0 +1,24 @@
+package example
+
+// CAUTION: DO NOT EDIT
+// Tests in this project rely on specific lines numbers
+// throughout this file.
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type suite struct {
+	is *is.I
+}
+
+func TestEmbeddedReceiver(t *testing.T) {
+	s := suite{is: is.New(t)}
+	a, b := 1, 2
+	getB := func() int {
+		return b
+	}
+	s.is.True(a == getB()) // should be the same
+}